@@ -0,0 +1,199 @@
+package provider
+
+import (
+	"context"
+	"crypto"
+	"crypto/rand"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/asn1"
+	"encoding/pem"
+	"fmt"
+	"net"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+func dataSourceCertificateRequest() *schema.Resource {
+	return &schema.Resource{
+		Description: "Parses a `CERTIFICATE REQUEST` (CSR) and re-emits it, either canonicalized as-is or " +
+			"re-signed under a different, locally-managed key, without having to regenerate its subject or " +
+			"SAN extensions.",
+
+		ReadContext: dataSourceCertificateRequestRead,
+
+		Schema: map[string]*schema.Schema{
+			"id": {
+				Type:        schema.TypeString,
+				Description: "A unique id for the converted certificate request.",
+				Computed:    true,
+			},
+
+			"input_csr_pem": {
+				Type:        schema.TypeString,
+				Description: "The certificate request, encoded as `PEM`.",
+				Required:    true,
+			},
+			"signing_key_pem": {
+				Type:        schema.TypeString,
+				Description: "A private key to re-sign the certificate request with. If unset, `output_csr_pem` is the canonicalized input, unchanged.",
+				Optional:    true,
+				Sensitive:   true,
+			},
+			"signing_key_format": {
+				Type: schema.TypeString,
+				Description: "The format of `signing_key_pem`.\n" +
+					"  \n" +
+					"  Supported formats: `PKCS#1`, `PKCS#8`. Required if `signing_key_pem` is set.",
+				Optional: true,
+			},
+
+			"output_csr_pem": {
+				Type:        schema.TypeString,
+				Description: "The converted certificate request, encoded as `PEM`.",
+				Computed:    true,
+			},
+			"subject": {
+				Type:        schema.TypeString,
+				Description: "The distinguished name of the certificate request's subject.",
+				Computed:    true,
+			},
+			"dns_names": {
+				Type:        schema.TypeList,
+				Description: "The DNS names requested as subject alternative names.",
+				Computed:    true,
+				Elem:        &schema.Schema{Type: schema.TypeString},
+			},
+			"ip_addresses": {
+				Type:        schema.TypeList,
+				Description: "The IP addresses requested as subject alternative names.",
+				Computed:    true,
+				Elem:        &schema.Schema{Type: schema.TypeString},
+			},
+			"extensions": {
+				Type:        schema.TypeSet,
+				Description: "The names (or, if unknown, dotted OIDs) of the X.509 extensions requested by the certificate request.",
+				Computed:    true,
+				Elem:        &schema.Schema{Type: schema.TypeString},
+			},
+		},
+	}
+}
+
+func dataSourceCertificateRequestRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	csr, err := decodeCertificateRequest(d.Get("input_csr_pem").(string))
+	if err != nil {
+		return diag.Errorf("Could not decode certificate request: %v", err)
+	}
+
+	var diags diag.Diagnostics
+	if signingKeyPEM, ok := d.GetOk("signing_key_pem"); ok {
+		signingKey, signingKeyDiags, err := decodeRSAPrivateKey(d.Get("signing_key_format").(string), signingKeyPEM.(string), "")
+		if err != nil {
+			return diag.Errorf("Could not decode signing key: %v", err)
+		}
+		diags = signingKeyDiags
+
+		csr, err = resignCertificateRequest(csr, signingKey)
+		if err != nil {
+			return diag.Errorf("Could not re-sign certificate request: %v", err)
+		}
+	}
+
+	output := encodeCertificateRequest(csr)
+	d.SetId(computeHash(output))
+	d.Set("output_csr_pem", output)
+	d.Set("subject", csr.Subject.String())
+	d.Set("dns_names", csr.DNSNames)
+	d.Set("ip_addresses", ipAddressesToStrings(csr.IPAddresses))
+	d.Set("extensions", extensionNames(csr.Extensions))
+
+	return diags
+}
+
+func decodeCertificateRequest(data string) (*x509.CertificateRequest, error) {
+	block, _ := pem.Decode([]byte(data))
+	if block == nil {
+		return nil, fmt.Errorf("Could not decode PEM block")
+	}
+	return x509.ParseCertificateRequest(block.Bytes)
+}
+
+func encodeCertificateRequest(csr *x509.CertificateRequest) string {
+	block := &pem.Block{
+		Type:  "CERTIFICATE REQUEST",
+		Bytes: csr.Raw,
+	}
+	return string(pem.EncodeToMemory(block))
+}
+
+// oidSubjectAltName is regenerated by x509.CreateCertificateRequest from the
+// template's DNSNames/EmailAddresses/IPAddresses/URIs fields, so it must be
+// excluded from ExtraExtensions below to avoid being duplicated.
+var oidSubjectAltName = asn1.ObjectIdentifier{2, 5, 29, 17}
+
+// resignCertificateRequest builds a new certificate request carrying csr's
+// subject, subject alternative names, and any other requested extensions
+// (e.g. keyUsage, extKeyUsage), signed by signingKey instead of whatever key
+// originally signed csr.
+func resignCertificateRequest(csr *x509.CertificateRequest, signingKey crypto.Signer) (*x509.CertificateRequest, error) {
+	template := &x509.CertificateRequest{
+		Subject:         csr.Subject,
+		DNSNames:        csr.DNSNames,
+		EmailAddresses:  csr.EmailAddresses,
+		IPAddresses:     csr.IPAddresses,
+		URIs:            csr.URIs,
+		ExtraExtensions: extraExtensions(csr.Extensions),
+	}
+
+	der, err := x509.CreateCertificateRequest(rand.Reader, template, signingKey)
+	if err != nil {
+		return nil, err
+	}
+	return x509.ParseCertificateRequest(der)
+}
+
+// extraExtensions returns extensions to carry over unchanged when re-signing,
+// excluding the subject alternative name extension that the template's
+// DNSNames/EmailAddresses/IPAddresses/URIs fields regenerate.
+func extraExtensions(extensions []pkix.Extension) []pkix.Extension {
+	extra := make([]pkix.Extension, 0, len(extensions))
+	for _, ext := range extensions {
+		if ext.Id.Equal(oidSubjectAltName) {
+			continue
+		}
+		extra = append(extra, ext)
+	}
+	return extra
+}
+
+func ipAddressesToStrings(ips []net.IP) []string {
+	out := make([]string, len(ips))
+	for i, ip := range ips {
+		out[i] = ip.String()
+	}
+	return out
+}
+
+// extensionOIDNames maps the dotted OIDs of commonly requested X.509
+// extensions to their familiar names.
+var extensionOIDNames = map[string]string{
+	"2.5.29.15": "keyUsage",
+	"2.5.29.17": "subjectAltName",
+	"2.5.29.19": "basicConstraints",
+	"2.5.29.37": "extKeyUsage",
+}
+
+func extensionNames(extensions []pkix.Extension) []string {
+	names := make([]string, len(extensions))
+	for i, ext := range extensions {
+		oid := ext.Id.String()
+		if name, ok := extensionOIDNames[oid]; ok {
+			names[i] = name
+		} else {
+			names[i] = oid
+		}
+	}
+	return names
+}
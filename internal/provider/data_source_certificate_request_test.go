@@ -0,0 +1,70 @@
+package provider
+
+import (
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/asn1"
+	"testing"
+)
+
+func TestResignCertificateRequestPreservesExtensions(t *testing.T) {
+	originalKey, err := rsa.GenerateKey(rand.Reader, 2048)
+	if err != nil {
+		t.Fatalf("GenerateKey: %v", err)
+	}
+
+	keyUsageExt := pkix.Extension{
+		Id:    asn1.ObjectIdentifier{2, 5, 29, 15},
+		Value: []byte{0x03, 0x02, 0x05, 0xA0}, // BIT STRING, an arbitrary keyUsage value
+	}
+	template := &x509.CertificateRequest{
+		Subject:         pkix.Name{CommonName: "example.com"},
+		DNSNames:        []string{"example.com", "www.example.com"},
+		ExtraExtensions: []pkix.Extension{keyUsageExt},
+	}
+
+	der, err := x509.CreateCertificateRequest(rand.Reader, template, originalKey)
+	if err != nil {
+		t.Fatalf("CreateCertificateRequest: %v", err)
+	}
+	csr, err := x509.ParseCertificateRequest(der)
+	if err != nil {
+		t.Fatalf("ParseCertificateRequest: %v", err)
+	}
+
+	signingKey, err := rsa.GenerateKey(rand.Reader, 2048)
+	if err != nil {
+		t.Fatalf("GenerateKey: %v", err)
+	}
+
+	resigned, err := resignCertificateRequest(csr, signingKey)
+	if err != nil {
+		t.Fatalf("resignCertificateRequest: %v", err)
+	}
+
+	if resigned.Subject.CommonName != "example.com" {
+		t.Errorf("Subject.CommonName = %q, want %q", resigned.Subject.CommonName, "example.com")
+	}
+	if len(resigned.DNSNames) != 2 || resigned.DNSNames[0] != "example.com" || resigned.DNSNames[1] != "www.example.com" {
+		t.Errorf("DNSNames = %v, want [example.com www.example.com]", resigned.DNSNames)
+	}
+
+	found := false
+	for _, ext := range resigned.Extensions {
+		if ext.Id.Equal(keyUsageExt.Id) {
+			found = true
+			if string(ext.Value) != string(keyUsageExt.Value) {
+				t.Errorf("keyUsage extension value = %x, want %x", ext.Value, keyUsageExt.Value)
+			}
+		}
+	}
+	if !found {
+		t.Errorf("resigned certificate request is missing the keyUsage extension")
+	}
+
+	if err := resigned.CheckSignature(); err != nil {
+		t.Errorf("resigned certificate request has an invalid signature: %v", err)
+	}
+}
@@ -0,0 +1,178 @@
+package provider
+
+import (
+	"context"
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/ed25519"
+	"crypto/rsa"
+	"crypto/x509"
+	"encoding/pem"
+	"fmt"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+func dataSourcePrivateKey() *schema.Resource {
+	return &schema.Resource{
+		Description: "Converts a private key between PEM and `OpenSSH` encodings. Unlike " +
+			"`tlsconvert_rsa_private_key`, this data source also supports ECDSA (P224/P256/P384/P521) and " +
+			"Ed25519 keys.",
+
+		ReadContext: dataSourcePrivateKeyRead,
+
+		Schema: map[string]*schema.Schema{
+			"id": {
+				Type:        schema.TypeString,
+				Description: "A unique id for the converted private key.",
+				Computed:    true,
+			},
+
+			"input_format": {
+				Type: schema.TypeString,
+				Description: "The format of the provided private key.\n" +
+					"  \n" +
+					"  Supported formats: `PKCS#1` (RSA only), `SEC1` (ECDSA only), `PKCS#8`, `OpenSSH`.",
+				Required: true,
+			},
+			"input_pem": {
+				Type:        schema.TypeString,
+				Description: "The private key, encoded as `PEM`.",
+				Required:    true,
+				Sensitive:   true,
+			},
+			"output_format": {
+				Type: schema.TypeString,
+				Description: "The format to convert the private key to.\n" +
+					"  \n" +
+					"  Supported formats: `PKCS#1` (RSA only), `SEC1` (ECDSA only), `PKCS#8`, `OpenSSH`.",
+				Required: true,
+			},
+			"ssh_comment": {
+				Type:        schema.TypeString,
+				Description: "The comment embedded in `output_pem` when `output_format = \"OpenSSH\"`. Ignored otherwise.",
+				Optional:    true,
+			},
+			"output_pem": {
+				Type:        schema.TypeString,
+				Description: "The converted private key, encoded as `PEM`.",
+				Computed:    true,
+				Sensitive:   true,
+			},
+		},
+	}
+}
+
+func dataSourcePrivateKeyRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	privateKey, err := decodePrivateKey(d.Get("input_format").(string), d.Get("input_pem").(string))
+	if err != nil {
+		return diag.Errorf("Could not decode private key: %v", err)
+	}
+
+	output, err := encodePrivateKey(d.Get("output_format").(string), privateKey, d.Get("ssh_comment").(string))
+	if err != nil {
+		return diag.Errorf("Could not encode private key: %v", err)
+	}
+	d.SetId(computeHash(output))
+	d.Set("output_pem", output)
+
+	return nil
+}
+
+// keyParsers decode the DER body of a PEM block for a given input format.
+// PKCS#8 is algorithm-agnostic: the concrete key type is only known once the
+// DER has been parsed, so callers detect it with a type switch on the
+// returned crypto.PrivateKey.
+var keyParsers = map[string]func([]byte) (crypto.PrivateKey, error){
+	"PKCS#1": func(der []byte) (crypto.PrivateKey, error) {
+		return x509.ParsePKCS1PrivateKey(der)
+	},
+	"SEC1": func(der []byte) (crypto.PrivateKey, error) {
+		return x509.ParseECPrivateKey(der)
+	},
+	"PKCS#8": func(der []byte) (crypto.PrivateKey, error) {
+		return x509.ParsePKCS8PrivateKey(der)
+	},
+}
+
+func decodePrivateKey(format string, data string) (crypto.PrivateKey, error) {
+	if format == "OpenSSH" {
+		key, err := decodeOpenSSHPrivateKey([]byte(data), "")
+		if err != nil {
+			return nil, err
+		}
+		// The ssh package returns Ed25519 keys by pointer; the encoders in
+		// keyAlgos and ssh.MarshalPrivateKey take the value form.
+		if edKey, ok := key.(*ed25519.PrivateKey); ok {
+			return *edKey, nil
+		}
+		return key, nil
+	}
+
+	block, _ := pem.Decode([]byte(data))
+	if block == nil {
+		return nil, fmt.Errorf("Could not decode PEM block")
+	}
+
+	parser, ok := keyParsers[format]
+	if !ok {
+		return nil, fmt.Errorf("Unknown format %v", format)
+	}
+	return parser(block.Bytes)
+}
+
+// keyAlgos enumerate which key types each output format accepts and how to
+// marshal them to DER. PKCS#8 accepts any of them, since it carries its own
+// algorithm identifier.
+var keyAlgos = map[string]func(crypto.PrivateKey) (*pem.Block, error){
+	"PKCS#1": func(key crypto.PrivateKey) (*pem.Block, error) {
+		rsaKey, ok := key.(*rsa.PrivateKey)
+		if !ok {
+			return nil, fmt.Errorf("Format PKCS#1 only supports RSA keys")
+		}
+		return &pem.Block{
+			Type:  "RSA PRIVATE KEY",
+			Bytes: x509.MarshalPKCS1PrivateKey(rsaKey),
+		}, nil
+	},
+	"SEC1": func(key crypto.PrivateKey) (*pem.Block, error) {
+		ecKey, ok := key.(*ecdsa.PrivateKey)
+		if !ok {
+			return nil, fmt.Errorf("Format SEC1 only supports ECDSA keys")
+		}
+		der, err := x509.MarshalECPrivateKey(ecKey)
+		if err != nil {
+			return nil, err
+		}
+		return &pem.Block{Type: "EC PRIVATE KEY", Bytes: der}, nil
+	},
+	"PKCS#8": func(key crypto.PrivateKey) (*pem.Block, error) {
+		der, err := x509.MarshalPKCS8PrivateKey(key)
+		if err != nil {
+			return nil, err
+		}
+		return &pem.Block{Type: "PRIVATE KEY", Bytes: der}, nil
+	},
+}
+
+func encodePrivateKey(format string, privateKey crypto.PrivateKey, comment string) (string, error) {
+	if format == "OpenSSH" {
+		signer, ok := privateKey.(crypto.Signer)
+		if !ok {
+			return "", fmt.Errorf("Key does not support signing, required for format OpenSSH")
+		}
+		return encodeOpenSSHPrivateKey(signer, comment, "")
+	}
+
+	marshal, ok := keyAlgos[format]
+	if !ok {
+		return "", fmt.Errorf("Unknown format %v", format)
+	}
+
+	block, err := marshal(privateKey)
+	if err != nil {
+		return "", err
+	}
+	return string(pem.EncodeToMemory(block)), nil
+}
@@ -0,0 +1,92 @@
+package provider
+
+import (
+	"crypto/ecdsa"
+	"crypto/ed25519"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/x509"
+	"encoding/pem"
+	"testing"
+)
+
+func TestPrivateKeyECDSARoundTrip(t *testing.T) {
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		t.Fatalf("GenerateKey: %v", err)
+	}
+	der, err := x509.MarshalPKCS8PrivateKey(key)
+	if err != nil {
+		t.Fatalf("MarshalPKCS8PrivateKey: %v", err)
+	}
+	pemData := string(pem.EncodeToMemory(&pem.Block{Type: "PRIVATE KEY", Bytes: der}))
+
+	decoded, err := decodePrivateKey("PKCS#8", pemData)
+	if err != nil {
+		t.Fatalf("decodePrivateKey: %v", err)
+	}
+	if _, ok := decoded.(*ecdsa.PrivateKey); !ok {
+		t.Fatalf("decodePrivateKey returned %T, want *ecdsa.PrivateKey", decoded)
+	}
+
+	output, err := encodePrivateKey("SEC1", decoded, "")
+	if err != nil {
+		t.Fatalf("encodePrivateKey: %v", err)
+	}
+
+	block, _ := pem.Decode([]byte(output))
+	if block == nil || block.Type != "EC PRIVATE KEY" {
+		t.Fatalf("encodePrivateKey did not produce an EC PRIVATE KEY block")
+	}
+	reparsed, err := x509.ParseECPrivateKey(block.Bytes)
+	if err != nil {
+		t.Fatalf("ParseECPrivateKey: %v", err)
+	}
+	if !reparsed.Equal(key) {
+		t.Errorf("round-tripped key does not match the original")
+	}
+}
+
+func TestPrivateKeyEd25519RoundTrip(t *testing.T) {
+	_, key, err := ed25519.GenerateKey(rand.Reader)
+	if err != nil {
+		t.Fatalf("GenerateKey: %v", err)
+	}
+	der, err := x509.MarshalPKCS8PrivateKey(key)
+	if err != nil {
+		t.Fatalf("MarshalPKCS8PrivateKey: %v", err)
+	}
+	pemData := string(pem.EncodeToMemory(&pem.Block{Type: "PRIVATE KEY", Bytes: der}))
+
+	decoded, err := decodePrivateKey("PKCS#8", pemData)
+	if err != nil {
+		t.Fatalf("decodePrivateKey: %v", err)
+	}
+	if _, ok := decoded.(ed25519.PrivateKey); !ok {
+		t.Fatalf("decodePrivateKey returned %T, want ed25519.PrivateKey", decoded)
+	}
+
+	output, err := encodePrivateKey("PKCS#8", decoded, "")
+	if err != nil {
+		t.Fatalf("encodePrivateKey: %v", err)
+	}
+
+	block, _ := pem.Decode([]byte(output))
+	if block == nil || block.Type != "PRIVATE KEY" {
+		t.Fatalf("encodePrivateKey did not produce a PRIVATE KEY block")
+	}
+	reparsed, err := x509.ParsePKCS8PrivateKey(block.Bytes)
+	if err != nil {
+		t.Fatalf("ParsePKCS8PrivateKey: %v", err)
+	}
+	reparsedKey, ok := reparsed.(ed25519.PrivateKey)
+	if !ok || !reparsedKey.Equal(key) {
+		t.Errorf("round-tripped key does not match the original")
+	}
+}
+
+func TestPrivateKeyUnknownFormat(t *testing.T) {
+	if _, err := decodePrivateKey("bogus", "irrelevant"); err == nil {
+		t.Fatalf("decodePrivateKey succeeded for an unknown format")
+	}
+}
@@ -2,6 +2,7 @@ package provider
 
 import (
 	"context"
+	"crypto/rand"
 	"crypto/rsa"
 	"crypto/sha256"
 	"crypto/x509"
@@ -15,7 +16,7 @@ import (
 
 func dataSourceRSAPrivateKey() *schema.Resource {
 	return &schema.Resource{
-		Description: "Converts a RSA private key (e.g. from `PKCS#1` to `PKCS#8`).",
+		Description: "Converts a RSA private key (e.g. from `PKCS#1` to `PKCS#8`, or to/from `OpenSSH`).",
 
 		ReadContext: dataSourceRSAPrivateKeyRead,
 
@@ -30,7 +31,7 @@ func dataSourceRSAPrivateKey() *schema.Resource {
 				Type: schema.TypeString,
 				Description: "The format of the provided private key.\n" +
 					"  \n" +
-					"  Supported formats: `PKCS#1`, `PKCS#8`.",
+					"  Supported formats: `PKCS#1`, `PKCS#8`, `OpenSSH`.",
 				Required: true,
 			},
 			"input_pem": {
@@ -39,13 +40,41 @@ func dataSourceRSAPrivateKey() *schema.Resource {
 				Required:    true,
 				Sensitive:   true,
 			},
+			"input_password": {
+				Type:        schema.TypeString,
+				Description: "The password protecting `input_pem`, if it is encrypted. Leave unset for an unencrypted key.",
+				Optional:    true,
+				Sensitive:   true,
+			},
 			"output_format": {
 				Type: schema.TypeString,
 				Description: "The format to convert the private key to.\n" +
 					"  \n" +
-					"  Supported formats: `PKCS#1`, `PKCS#8`.",
+					"  Supported formats: `PKCS#1`, `PKCS#8`, `OpenSSH`.",
 				Required: true,
 			},
+			"ssh_comment": {
+				Type:        schema.TypeString,
+				Description: "The comment embedded in `output_pem` when `output_format = \"OpenSSH\"`. Ignored otherwise.",
+				Optional:    true,
+			},
+			"output_password": {
+				Type:        schema.TypeString,
+				Description: "A password to encrypt `output_pem` with. Leave unset to emit an unencrypted key.",
+				Optional:    true,
+				Sensitive:   true,
+			},
+			"output_encryption": {
+				Type: schema.TypeString,
+				Description: "The scheme used to encrypt `output_pem` when `output_password` is set.\n" +
+					"  \n" +
+					"  Supported schemes: `legacy-aes256` (the traditional `DEK-Info`-style encrypted PEM; " +
+					"deprecated but widely understood), `pkcs8-pbes2` (a PKCS#8 `EncryptedPrivateKeyInfo` using " +
+					"PBKDF2 and AES-256-CBC; requires `output_format = \"PKCS#8\"`). Defaults to `legacy-aes256`. " +
+					"Ignored when `output_format = \"OpenSSH\"`, which always uses OpenSSH's own encryption.",
+				Optional: true,
+				Default:  "legacy-aes256",
+			},
 			"output_pem": {
 				Type:        schema.TypeString,
 				Description: "The converted private key, encoded as `PEM`.",
@@ -57,47 +86,121 @@ func dataSourceRSAPrivateKey() *schema.Resource {
 }
 
 func dataSourceRSAPrivateKeyRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
-	privateKey, err := decodeRSAPrivateKey(d.Get("input_format").(string), d.Get("input_pem").(string))
+	privateKey, diags, err := decodeRSAPrivateKey(
+		d.Get("input_format").(string), d.Get("input_pem").(string), d.Get("input_password").(string))
 	if err != nil {
 		return diag.Errorf("Could not decode private key: %v", err)
 	}
 
-	output, err := encodeRSAPrivateKey(d.Get("output_format").(string), privateKey)
+	output, err := encodeRSAPrivateKey(
+		d.Get("output_format").(string), privateKey, d.Get("output_password").(string),
+		d.Get("output_encryption").(string), d.Get("ssh_comment").(string))
 	if err != nil {
 		return diag.Errorf("Could not encode private key: %v", err)
 	}
 	d.SetId(computeHash(output))
 	d.Set("output_pem", output)
 
-	return nil
+	return diags
+}
+
+// rsaKeyParsers are tried, in order, against the PEM body: first the format
+// declared by the caller, then the others. Real-world keys sometimes carry a
+// PEM header (e.g. `RSA PRIVATE KEY`) that does not match the body's actual
+// encoding, so falling back lets those keys decode anyway.
+var rsaKeyParsers = map[string]func([]byte) (*rsa.PrivateKey, error){
+	"PKCS#1": func(der []byte) (*rsa.PrivateKey, error) {
+		return x509.ParsePKCS1PrivateKey(der)
+	},
+	"PKCS#8": func(der []byte) (*rsa.PrivateKey, error) {
+		rawKey, err := x509.ParsePKCS8PrivateKey(der)
+		if err != nil {
+			return nil, err
+		}
+		key, ok := rawKey.(*rsa.PrivateKey)
+		if !ok {
+			return nil, fmt.Errorf("Illegal key for format PKCS#8")
+		}
+		return key, nil
+	},
 }
 
-func decodeRSAPrivateKey(format string, data string) (*rsa.PrivateKey, error) {
+func decodeRSAPrivateKey(format string, data string, password string) (*rsa.PrivateKey, diag.Diagnostics, error) {
+	if format == "OpenSSH" {
+		rawKey, err := decodeOpenSSHPrivateKey([]byte(data), password)
+		if err != nil {
+			return nil, nil, err
+		}
+		key, ok := rawKey.(*rsa.PrivateKey)
+		if !ok {
+			return nil, nil, fmt.Errorf("Illegal key for format OpenSSH")
+		}
+		return key, nil, nil
+	}
+
 	block, _ := pem.Decode([]byte(data))
+	if block == nil {
+		return nil, nil, fmt.Errorf("Could not decode PEM block")
+	}
 
-	switch format {
-	case "PKCS#1":
-		return x509.ParsePKCS1PrivateKey(block.Bytes)
+	der, err := decryptPEMBlock(block, password)
+	if err != nil {
+		return nil, nil, err
+	}
 
-	case "PKCS#8":
-		{
-			rawKey, err := x509.ParsePKCS8PrivateKey(block.Bytes)
-			if err != nil {
-				return nil, err
-			}
-			key, ok := rawKey.(*rsa.PrivateKey)
-			if !ok {
-				return nil, fmt.Errorf("Illegal key for format PKCS#8")
-			}
-			return key, nil
+	parser, ok := rsaKeyParsers[format]
+	if !ok {
+		return nil, nil, fmt.Errorf("Unknown format %v", format)
+	}
+	if key, err := parser(der); err == nil {
+		return key, nil, nil
+	}
+
+	for actualFormat, fallback := range rsaKeyParsers {
+		if actualFormat == format {
+			continue
 		}
+		if key, err := fallback(der); err == nil {
+			return key, diag.Diagnostics{{
+				Severity: diag.Warning,
+				Summary:  "input_format did not match the PEM body",
+				Detail: fmt.Sprintf("input_format was %q, but the PEM body actually decodes as %q. "+
+					"The key was decoded anyway; consider fixing the PEM header to avoid relying on this fallback.",
+					format, actualFormat),
+			}}, nil
+		}
+	}
 
-	default:
-		return nil, fmt.Errorf("Unknown format %v", format)
+	return nil, nil, fmt.Errorf("Could not decode private key as %v", format)
+}
+
+// decryptPEMBlock returns the (possibly decrypted) DER body of block. It
+// understands both the legacy `DEK-Info`-style encrypted PEM produced by
+// `x509.EncryptPEMBlock`, and the PKCS#8 `ENCRYPTED PRIVATE KEY` form
+// produced by encryptPKCS8.
+func decryptPEMBlock(block *pem.Block, password string) ([]byte, error) {
+	if block.Type == "ENCRYPTED PRIVATE KEY" {
+		if password == "" {
+			return nil, fmt.Errorf("private key is encrypted (PKCS#8) but input_password was not set")
+		}
+		return decryptPKCS8(block.Bytes, []byte(password))
 	}
+
+	if x509.IsEncryptedPEMBlock(block) { //nolint:staticcheck // legacy format still widely produced
+		if password == "" {
+			return nil, fmt.Errorf("private key is encrypted but input_password was not set")
+		}
+		return x509.DecryptPEMBlock(block, []byte(password)) //nolint:staticcheck
+	}
+
+	return block.Bytes, nil
 }
 
-func encodeRSAPrivateKey(format string, privateKey *rsa.PrivateKey) (string, error) {
+func encodeRSAPrivateKey(format string, privateKey *rsa.PrivateKey, password string, encryption string, comment string) (string, error) {
+	if format == "OpenSSH" {
+		return encodeOpenSSHPrivateKey(privateKey, comment, password)
+	}
+
 	var block *pem.Block
 	switch format {
 	case "PKCS#1":
@@ -122,7 +225,37 @@ func encodeRSAPrivateKey(format string, privateKey *rsa.PrivateKey) (string, err
 		return "", fmt.Errorf("Unknown format %v", format)
 	}
 
-	return string(pem.EncodeToMemory(block)), nil
+	if password == "" {
+		return string(pem.EncodeToMemory(block)), nil
+	}
+
+	encryptedBlock, err := encryptPEMBlock(block, password, encryption)
+	if err != nil {
+		return "", err
+	}
+	return string(pem.EncodeToMemory(encryptedBlock)), nil
+}
+
+// encryptPEMBlock encrypts block's DER bytes under the given output_encryption
+// scheme, returning a new block ready for pem.EncodeToMemory.
+func encryptPEMBlock(block *pem.Block, password string, encryption string) (*pem.Block, error) {
+	switch encryption {
+	case "", "legacy-aes256":
+		return x509.EncryptPEMBlock(rand.Reader, block.Type, block.Bytes, []byte(password), x509.PEMCipherAES256) //nolint:staticcheck
+
+	case "pkcs8-pbes2":
+		if block.Type != "PRIVATE KEY" {
+			return nil, fmt.Errorf("output_encryption = \"pkcs8-pbes2\" requires output_format = \"PKCS#8\"")
+		}
+		encryptedDER, err := encryptPKCS8(block.Bytes, []byte(password))
+		if err != nil {
+			return nil, err
+		}
+		return &pem.Block{Type: "ENCRYPTED PRIVATE KEY", Bytes: encryptedDER}, nil
+
+	default:
+		return nil, fmt.Errorf("Unknown output_encryption %v", encryption)
+	}
 }
 
 func computeHash(s string) string {
@@ -0,0 +1,92 @@
+package provider
+
+import (
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/x509"
+	"encoding/pem"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+)
+
+func testRSAKey(t *testing.T) *rsa.PrivateKey {
+	t.Helper()
+	key, err := rsa.GenerateKey(rand.Reader, 2048)
+	if err != nil {
+		t.Fatalf("GenerateKey: %v", err)
+	}
+	return key
+}
+
+// TestDecodeRSAPrivateKeyMismatchedHeaderFallsBack verifies that a PEM body
+// encoded as PKCS#8 but declared (e.g. via a mislabeled header) as PKCS#1
+// still decodes, with a warning diagnostic pointing at the actual format.
+func TestDecodeRSAPrivateKeyMismatchedHeaderFallsBack(t *testing.T) {
+	key := testRSAKey(t)
+	der, err := x509.MarshalPKCS8PrivateKey(key)
+	if err != nil {
+		t.Fatalf("MarshalPKCS8PrivateKey: %v", err)
+	}
+	// Deliberately mislabeled: the body is PKCS#8 but the header says PKCS#1.
+	pemData := string(pem.EncodeToMemory(&pem.Block{Type: "RSA PRIVATE KEY", Bytes: der}))
+
+	decoded, diags, err := decodeRSAPrivateKey("PKCS#1", pemData, "")
+	if err != nil {
+		t.Fatalf("decodeRSAPrivateKey: %v", err)
+	}
+	if !decoded.Equal(key) {
+		t.Errorf("decoded key does not match the original")
+	}
+	if len(diags) != 1 || diags[0].Severity != diag.Warning {
+		t.Errorf("decodeRSAPrivateKey diags = %v, want a single warning", diags)
+	}
+}
+
+func TestDecodeRSAPrivateKeyUnknownFormat(t *testing.T) {
+	key := testRSAKey(t)
+	der := x509.MarshalPKCS1PrivateKey(key)
+	pemData := string(pem.EncodeToMemory(&pem.Block{Type: "RSA PRIVATE KEY", Bytes: der}))
+
+	if _, _, err := decodeRSAPrivateKey("bogus", pemData, ""); err == nil {
+		t.Fatalf("decodeRSAPrivateKey succeeded for an unknown format")
+	}
+}
+
+func TestRSAPrivateKeyLegacyEncryptedRoundTrip(t *testing.T) {
+	key := testRSAKey(t)
+
+	encoded, err := encodeRSAPrivateKey("PKCS#1", key, "correct horse battery staple", "legacy-aes256", "")
+	if err != nil {
+		t.Fatalf("encodeRSAPrivateKey: %v", err)
+	}
+
+	if _, _, err := decodeRSAPrivateKey("PKCS#1", encoded, ""); err == nil {
+		t.Fatalf("decodeRSAPrivateKey succeeded without a password on an encrypted key")
+	}
+
+	decoded, _, err := decodeRSAPrivateKey("PKCS#1", encoded, "correct horse battery staple")
+	if err != nil {
+		t.Fatalf("decodeRSAPrivateKey: %v", err)
+	}
+	if !decoded.Equal(key) {
+		t.Errorf("decoded key does not match the original")
+	}
+}
+
+func TestRSAPrivateKeyPKCS8EncryptedRoundTrip(t *testing.T) {
+	key := testRSAKey(t)
+
+	encoded, err := encodeRSAPrivateKey("PKCS#8", key, "correct horse battery staple", "pkcs8-pbes2", "")
+	if err != nil {
+		t.Fatalf("encodeRSAPrivateKey: %v", err)
+	}
+
+	decoded, _, err := decodeRSAPrivateKey("PKCS#8", encoded, "correct horse battery staple")
+	if err != nil {
+		t.Fatalf("decodeRSAPrivateKey: %v", err)
+	}
+	if !decoded.Equal(key) {
+		t.Errorf("decoded key does not match the original")
+	}
+}
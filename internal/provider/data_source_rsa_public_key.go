@@ -0,0 +1,188 @@
+package provider
+
+import (
+	"context"
+	"crypto/rsa"
+	"crypto/x509"
+	"encoding/pem"
+	"fmt"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+func dataSourceRSAPublicKey() *schema.Resource {
+	return &schema.Resource{
+		Description: "Converts a RSA public key between `PKCS#1`, `PKIX`/`SPKI` and `OpenSSH` (authorized_keys) " +
+			"encodings, or derives it from a RSA private key.",
+
+		ReadContext: dataSourceRSAPublicKeyRead,
+
+		Schema: map[string]*schema.Schema{
+			"id": {
+				Type:        schema.TypeString,
+				Description: "A unique id for the converted public key.",
+				Computed:    true,
+			},
+
+			"input_source": {
+				Type: schema.TypeString,
+				Description: "Where to read the input key from.\n" +
+					"  \n" +
+					"  Supported sources: `public_key` (parse `input_pem` as a public key), `private_key` " +
+					"(derive the public key from `input_pem`, a private key). Defaults to `public_key`.",
+				Optional: true,
+				Default:  "public_key",
+			},
+			"input_format": {
+				Type: schema.TypeString,
+				Description: "The format of the provided key.\n" +
+					"  \n" +
+					"  When `input_source = \"public_key\"`, supported formats are `PKCS#1`, `PKIX` and `OpenSSH` " +
+					"(a single `ssh-rsa AAAA...` authorized_keys line).\n" +
+					"  When `input_source = \"private_key\"`, supported formats are `PKCS#1`, `PKCS#8` and `OpenSSH`.",
+				Required: true,
+			},
+			"input_pem": {
+				Type: schema.TypeString,
+				Description: "The key, encoded as `PEM` (or, for `input_format = \"OpenSSH\"` with " +
+					"`input_source = \"public_key\"`, a single authorized_keys line).",
+				Required:  true,
+				Sensitive: true,
+			},
+			"input_password": {
+				Type: schema.TypeString,
+				Description: "The password protecting `input_pem`, if `input_source = \"private_key\"` and the " +
+					"private key is encrypted. Leave unset for an unencrypted key.",
+				Optional:  true,
+				Sensitive: true,
+			},
+			"output_format": {
+				Type: schema.TypeString,
+				Description: "The format to convert the public key to.\n" +
+					"  \n" +
+					"  Supported formats: `PKCS#1`, `PKIX`, `OpenSSH` (emits a single authorized_keys line).",
+				Required: true,
+			},
+			"ssh_comment": {
+				Type: schema.TypeString,
+				Description: "The comment appended to `output_pem` when `output_format = \"OpenSSH\"`. " +
+					"Ignored otherwise.",
+				Optional: true,
+			},
+			"output_pem": {
+				Type: schema.TypeString,
+				Description: "The converted public key, encoded as `PEM` (or, for `output_format = \"OpenSSH\"`, " +
+					"a single authorized_keys line).",
+				Computed: true,
+			},
+		},
+	}
+}
+
+func dataSourceRSAPublicKeyRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	publicKey, diags, err := decodeRSAPublicKeyInput(
+		d.Get("input_source").(string), d.Get("input_format").(string),
+		d.Get("input_pem").(string), d.Get("input_password").(string))
+	if err != nil {
+		return diag.Errorf("Could not decode public key: %v", err)
+	}
+
+	output, err := encodeRSAPublicKey(d.Get("output_format").(string), publicKey, d.Get("ssh_comment").(string))
+	if err != nil {
+		return diag.Errorf("Could not encode public key: %v", err)
+	}
+	d.SetId(computeHash(output))
+	d.Set("output_pem", output)
+
+	return diags
+}
+
+func decodeRSAPublicKeyInput(source string, format string, data string, password string) (*rsa.PublicKey, diag.Diagnostics, error) {
+	switch source {
+	case "public_key":
+		if format == "OpenSSH" {
+			rawKey, err := decodeOpenSSHPublicKey(data)
+			if err != nil {
+				return nil, nil, err
+			}
+			key, ok := rawKey.(*rsa.PublicKey)
+			if !ok {
+				return nil, nil, fmt.Errorf("Illegal key for format OpenSSH")
+			}
+			return key, nil, nil
+		}
+		key, err := decodeRSAPublicKey(format, data)
+		return key, nil, err
+
+	case "private_key":
+		privateKey, diags, err := decodeRSAPrivateKey(format, data, password)
+		if err != nil {
+			return nil, nil, err
+		}
+		return &privateKey.PublicKey, diags, nil
+
+	default:
+		return nil, nil, fmt.Errorf("Unknown input_source %v", source)
+	}
+}
+
+func decodeRSAPublicKey(format string, data string) (*rsa.PublicKey, error) {
+	block, _ := pem.Decode([]byte(data))
+	if block == nil {
+		return nil, fmt.Errorf("Could not decode PEM block")
+	}
+
+	switch format {
+	case "PKCS#1":
+		return x509.ParsePKCS1PublicKey(block.Bytes)
+
+	case "PKIX":
+		{
+			rawKey, err := x509.ParsePKIXPublicKey(block.Bytes)
+			if err != nil {
+				return nil, err
+			}
+			key, ok := rawKey.(*rsa.PublicKey)
+			if !ok {
+				return nil, fmt.Errorf("Illegal key for format PKIX")
+			}
+			return key, nil
+		}
+
+	default:
+		return nil, fmt.Errorf("Unknown format %v", format)
+	}
+}
+
+func encodeRSAPublicKey(format string, publicKey *rsa.PublicKey, comment string) (string, error) {
+	if format == "OpenSSH" {
+		return encodeOpenSSHPublicKey(publicKey, comment)
+	}
+
+	var block *pem.Block
+	switch format {
+	case "PKCS#1":
+		block = &pem.Block{
+			Type:  "RSA PUBLIC KEY",
+			Bytes: x509.MarshalPKCS1PublicKey(publicKey),
+		}
+
+	case "PKIX":
+		{
+			bytes, err := x509.MarshalPKIXPublicKey(publicKey)
+			if err != nil {
+				return "", err
+			}
+			block = &pem.Block{
+				Type:  "PUBLIC KEY",
+				Bytes: bytes,
+			}
+		}
+
+	default:
+		return "", fmt.Errorf("Unknown format %v", format)
+	}
+
+	return string(pem.EncodeToMemory(block)), nil
+}
@@ -0,0 +1,61 @@
+package provider
+
+import (
+	"crypto/x509"
+	"encoding/pem"
+	"testing"
+)
+
+func TestRSAPublicKeyPKIXRoundTrip(t *testing.T) {
+	key := testRSAKey(t)
+
+	encoded, err := encodeRSAPublicKey("PKIX", &key.PublicKey, "")
+	if err != nil {
+		t.Fatalf("encodeRSAPublicKey: %v", err)
+	}
+
+	decoded, err := decodeRSAPublicKey("PKIX", encoded)
+	if err != nil {
+		t.Fatalf("decodeRSAPublicKey: %v", err)
+	}
+	if !decoded.Equal(&key.PublicKey) {
+		t.Errorf("round-tripped public key does not match the original")
+	}
+}
+
+func TestRSAPublicKeyPKCS1RoundTrip(t *testing.T) {
+	key := testRSAKey(t)
+
+	encoded, err := encodeRSAPublicKey("PKCS#1", &key.PublicKey, "")
+	if err != nil {
+		t.Fatalf("encodeRSAPublicKey: %v", err)
+	}
+
+	decoded, err := decodeRSAPublicKey("PKCS#1", encoded)
+	if err != nil {
+		t.Fatalf("decodeRSAPublicKey: %v", err)
+	}
+	if !decoded.Equal(&key.PublicKey) {
+		t.Errorf("round-tripped public key does not match the original")
+	}
+}
+
+func TestDecodeRSAPublicKeyInputDerivedFromPrivateKey(t *testing.T) {
+	key := testRSAKey(t)
+	der := x509.MarshalPKCS1PrivateKey(key)
+	pemData := string(pem.EncodeToMemory(&pem.Block{Type: "RSA PRIVATE KEY", Bytes: der}))
+
+	publicKey, _, err := decodeRSAPublicKeyInput("private_key", "PKCS#1", pemData, "")
+	if err != nil {
+		t.Fatalf("decodeRSAPublicKeyInput: %v", err)
+	}
+	if !publicKey.Equal(&key.PublicKey) {
+		t.Errorf("derived public key does not match the original private key's public key")
+	}
+}
+
+func TestDecodeRSAPublicKeyInputUnknownSource(t *testing.T) {
+	if _, _, err := decodeRSAPublicKeyInput("bogus", "PKCS#1", "irrelevant", ""); err == nil {
+		t.Fatalf("decodeRSAPublicKeyInput succeeded for an unknown input_source")
+	}
+}
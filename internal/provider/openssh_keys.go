@@ -0,0 +1,74 @@
+package provider
+
+import (
+	"crypto"
+	"encoding/pem"
+	"fmt"
+	"strings"
+
+	"golang.org/x/crypto/ssh"
+)
+
+// decodeOpenSSHPrivateKey parses an `OPENSSH PRIVATE KEY` PEM block. Unlike
+// the x509 formats, OpenSSH's own encryption is baked into the blob, so it is
+// handled by the ssh package rather than decryptPEMBlock. It is
+// algorithm-generic: the concrete key type returned (RSA, ECDSA or Ed25519)
+// depends on what the blob embeds.
+func decodeOpenSSHPrivateKey(data []byte, password string) (crypto.PrivateKey, error) {
+	if password != "" {
+		return ssh.ParseRawPrivateKeyWithPassphrase(data, []byte(password))
+	}
+	return ssh.ParseRawPrivateKey(data)
+}
+
+// encodeOpenSSHPrivateKey marshals privateKey as an `OPENSSH PRIVATE KEY` PEM
+// block, using OpenSSH's own bcrypt-based encryption when password is set
+// rather than output_encryption (which only applies to the x509 formats).
+func encodeOpenSSHPrivateKey(privateKey crypto.Signer, comment string, password string) (string, error) {
+	var (
+		block *pem.Block
+		err   error
+	)
+	if password != "" {
+		block, err = ssh.MarshalPrivateKeyWithPassphrase(privateKey, comment, []byte(password))
+	} else {
+		block, err = ssh.MarshalPrivateKey(privateKey, comment)
+	}
+	if err != nil {
+		return "", err
+	}
+	return string(pem.EncodeToMemory(block)), nil
+}
+
+// decodeOpenSSHPublicKey parses a single `ssh-rsa AAAA... comment` (or
+// `ecdsa-sha2-...`/`ssh-ed25519`) authorized_keys line. Unlike the x509
+// formats it is not PEM-encoded, so it is parsed separately from the x509
+// pem.Decode path.
+func decodeOpenSSHPublicKey(data string) (crypto.PublicKey, error) {
+	sshKey, _, _, _, err := ssh.ParseAuthorizedKey([]byte(data))
+	if err != nil {
+		return nil, err
+	}
+
+	cryptoKey, ok := sshKey.(ssh.CryptoPublicKey)
+	if !ok {
+		return nil, fmt.Errorf("Illegal key for format OpenSSH")
+	}
+	return cryptoKey.CryptoPublicKey(), nil
+}
+
+// encodeOpenSSHPublicKey emits a single authorized_keys line. Unlike the
+// x509 formats it is not PEM-encoded, and ssh.MarshalAuthorizedKey does not
+// include a comment, so it is appended separately.
+func encodeOpenSSHPublicKey(publicKey crypto.PublicKey, comment string) (string, error) {
+	sshKey, err := ssh.NewPublicKey(publicKey)
+	if err != nil {
+		return "", err
+	}
+
+	line := string(ssh.MarshalAuthorizedKey(sshKey))
+	if comment != "" {
+		line = strings.TrimRight(line, "\n") + " " + comment + "\n"
+	}
+	return line, nil
+}
@@ -0,0 +1,103 @@
+package provider
+
+import (
+	"crypto/ed25519"
+	"crypto/rand"
+	"crypto/rsa"
+	"strings"
+	"testing"
+)
+
+func TestOpenSSHPrivateKeyRoundTrip(t *testing.T) {
+	key := testRSAKey(t)
+
+	encoded, err := encodeOpenSSHPrivateKey(key, "test-comment", "")
+	if err != nil {
+		t.Fatalf("encodeOpenSSHPrivateKey: %v", err)
+	}
+
+	decoded, err := decodeOpenSSHPrivateKey([]byte(encoded), "")
+	if err != nil {
+		t.Fatalf("decodeOpenSSHPrivateKey: %v", err)
+	}
+	decodedKey, ok := decoded.(*rsa.PrivateKey)
+	if !ok {
+		t.Fatalf("decodeOpenSSHPrivateKey returned %T, want *rsa.PrivateKey", decoded)
+	}
+	if !decodedKey.Equal(key) {
+		t.Errorf("decoded key does not match the original")
+	}
+}
+
+func TestOpenSSHPrivateKeyEncryptedRoundTrip(t *testing.T) {
+	key := testRSAKey(t)
+
+	encoded, err := encodeOpenSSHPrivateKey(key, "", "correct horse battery staple")
+	if err != nil {
+		t.Fatalf("encodeOpenSSHPrivateKey: %v", err)
+	}
+
+	if _, err := decodeOpenSSHPrivateKey([]byte(encoded), ""); err == nil {
+		t.Fatalf("decodeOpenSSHPrivateKey succeeded without a passphrase on an encrypted key")
+	}
+
+	decoded, err := decodeOpenSSHPrivateKey([]byte(encoded), "correct horse battery staple")
+	if err != nil {
+		t.Fatalf("decodeOpenSSHPrivateKey: %v", err)
+	}
+	decodedKey, ok := decoded.(*rsa.PrivateKey)
+	if !ok {
+		t.Fatalf("decodeOpenSSHPrivateKey returned %T, want *rsa.PrivateKey", decoded)
+	}
+	if !decodedKey.Equal(key) {
+		t.Errorf("decoded key does not match the original")
+	}
+}
+
+func TestOpenSSHPrivateKeyEd25519RoundTrip(t *testing.T) {
+	_, key, err := ed25519.GenerateKey(rand.Reader)
+	if err != nil {
+		t.Fatalf("GenerateKey: %v", err)
+	}
+
+	encoded, err := encodeOpenSSHPrivateKey(key, "test-comment", "")
+	if err != nil {
+		t.Fatalf("encodeOpenSSHPrivateKey: %v", err)
+	}
+
+	decoded, err := decodeOpenSSHPrivateKey([]byte(encoded), "")
+	if err != nil {
+		t.Fatalf("decodeOpenSSHPrivateKey: %v", err)
+	}
+	decodedKey, ok := decoded.(*ed25519.PrivateKey)
+	if !ok {
+		t.Fatalf("decodeOpenSSHPrivateKey returned %T, want *ed25519.PrivateKey", decoded)
+	}
+	if !decodedKey.Equal(key) {
+		t.Errorf("decoded key does not match the original")
+	}
+}
+
+func TestOpenSSHPublicKeyRoundTrip(t *testing.T) {
+	key := testRSAKey(t)
+
+	encoded, err := encodeOpenSSHPublicKey(&key.PublicKey, "test-comment")
+	if err != nil {
+		t.Fatalf("encodeOpenSSHPublicKey: %v", err)
+	}
+	if !strings.HasSuffix(strings.TrimRight(encoded, "\n"), "test-comment") {
+		t.Errorf("encodeOpenSSHPublicKey output %q does not end with the comment", encoded)
+	}
+
+	decoded, err := decodeOpenSSHPublicKey(encoded)
+	if err != nil {
+		t.Fatalf("decodeOpenSSHPublicKey: %v", err)
+	}
+	decodedKey, ok := decoded.(*rsa.PublicKey)
+	if !ok {
+		t.Fatalf("decodeOpenSSHPublicKey returned %T, want *rsa.PublicKey", decoded)
+	}
+	if !decodedKey.Equal(&key.PublicKey) {
+		t.Errorf("decoded public key does not match the original")
+	}
+}
@@ -0,0 +1,172 @@
+package provider
+
+import (
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/rand"
+	"crypto/sha256"
+	"crypto/x509/pkix"
+	"encoding/asn1"
+	"fmt"
+
+	"golang.org/x/crypto/pbkdf2"
+)
+
+// This file implements PKCS#8 EncryptedPrivateKeyInfo (RFC 5958) using
+// PBES2/PBKDF2 with AES-256-CBC, since crypto/x509's EncryptPEMBlock /
+// DecryptPEMBlock only produce the legacy, OpenSSL-specific "Proc-Type:
+// ENCRYPTED" PEM headers, which modern OpenSSL treats as deprecated and some
+// tooling refuses to read at all.
+
+var (
+	oidPBES2          = asn1.ObjectIdentifier{1, 2, 840, 113549, 1, 5, 13}
+	oidPBKDF2         = asn1.ObjectIdentifier{1, 2, 840, 113549, 1, 5, 12}
+	oidHMACWithSHA256 = asn1.ObjectIdentifier{1, 2, 840, 113549, 2, 9}
+	oidAES256CBC      = asn1.ObjectIdentifier{2, 16, 840, 1, 101, 3, 4, 1, 42}
+)
+
+const (
+	pbkdf2Iterations = 210000
+	pbkdf2SaltLen    = 16
+	aes256KeyLen     = 32
+	aesBlockLen      = 16
+)
+
+type pbkdf2Params struct {
+	Salt           []byte
+	IterationCount int
+	PRF            pkix.AlgorithmIdentifier
+}
+
+type pbes2Params struct {
+	KeyDerivationFunc pkix.AlgorithmIdentifier
+	EncryptionScheme  pkix.AlgorithmIdentifier
+}
+
+type encryptedPrivateKeyInfo struct {
+	Algorithm     pkix.AlgorithmIdentifier
+	EncryptedData []byte
+}
+
+// encryptPKCS8 wraps der (a PKCS#8 PrivateKeyInfo) in a PBES2-encrypted
+// EncryptedPrivateKeyInfo and returns its DER encoding.
+func encryptPKCS8(der []byte, password []byte) ([]byte, error) {
+	salt := make([]byte, pbkdf2SaltLen)
+	if _, err := rand.Read(salt); err != nil {
+		return nil, err
+	}
+	iv := make([]byte, aesBlockLen)
+	if _, err := rand.Read(iv); err != nil {
+		return nil, err
+	}
+
+	key := pbkdf2.Key(password, salt, pbkdf2Iterations, aes256KeyLen, sha256.New)
+	block, err := aes.NewCipher(key)
+	if err != nil {
+		return nil, err
+	}
+
+	padded := pkcs7Pad(der, aesBlockLen)
+	ciphertext := make([]byte, len(padded))
+	cipher.NewCBCEncrypter(block, iv).CryptBlocks(ciphertext, padded)
+
+	kdfParams, err := asn1.Marshal(pbkdf2Params{
+		Salt:           salt,
+		IterationCount: pbkdf2Iterations,
+		PRF:            pkix.AlgorithmIdentifier{Algorithm: oidHMACWithSHA256},
+	})
+	if err != nil {
+		return nil, err
+	}
+	ivParam, err := asn1.Marshal(iv)
+	if err != nil {
+		return nil, err
+	}
+	schemeParams, err := asn1.Marshal(pbes2Params{
+		KeyDerivationFunc: pkix.AlgorithmIdentifier{Algorithm: oidPBKDF2, Parameters: asn1.RawValue{FullBytes: kdfParams}},
+		EncryptionScheme:  pkix.AlgorithmIdentifier{Algorithm: oidAES256CBC, Parameters: asn1.RawValue{FullBytes: ivParam}},
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return asn1.Marshal(encryptedPrivateKeyInfo{
+		Algorithm:     pkix.AlgorithmIdentifier{Algorithm: oidPBES2, Parameters: asn1.RawValue{FullBytes: schemeParams}},
+		EncryptedData: ciphertext,
+	})
+}
+
+// decryptPKCS8 reverses encryptPKCS8, returning the original PKCS#8
+// PrivateKeyInfo DER.
+func decryptPKCS8(der []byte, password []byte) ([]byte, error) {
+	var info encryptedPrivateKeyInfo
+	if _, err := asn1.Unmarshal(der, &info); err != nil {
+		return nil, fmt.Errorf("Not a PKCS#8 EncryptedPrivateKeyInfo: %w", err)
+	}
+	if !info.Algorithm.Algorithm.Equal(oidPBES2) {
+		return nil, fmt.Errorf("Unsupported PKCS#8 encryption algorithm %v, only PBES2 is supported", info.Algorithm.Algorithm)
+	}
+
+	var scheme pbes2Params
+	if _, err := asn1.Unmarshal(info.Algorithm.Parameters.FullBytes, &scheme); err != nil {
+		return nil, fmt.Errorf("Could not parse PBES2 parameters: %w", err)
+	}
+	if !scheme.KeyDerivationFunc.Algorithm.Equal(oidPBKDF2) {
+		return nil, fmt.Errorf("Unsupported key derivation function %v, only PBKDF2 is supported", scheme.KeyDerivationFunc.Algorithm)
+	}
+	if !scheme.EncryptionScheme.Algorithm.Equal(oidAES256CBC) {
+		return nil, fmt.Errorf("Unsupported encryption scheme %v, only AES-256-CBC is supported", scheme.EncryptionScheme.Algorithm)
+	}
+
+	var kdf pbkdf2Params
+	if _, err := asn1.Unmarshal(scheme.KeyDerivationFunc.Parameters.FullBytes, &kdf); err != nil {
+		return nil, fmt.Errorf("Could not parse PBKDF2 parameters: %w", err)
+	}
+	var iv []byte
+	if _, err := asn1.Unmarshal(scheme.EncryptionScheme.Parameters.FullBytes, &iv); err != nil {
+		return nil, fmt.Errorf("Could not parse AES-256-CBC IV: %w", err)
+	}
+
+	key := pbkdf2.Key(password, kdf.Salt, kdf.IterationCount, aes256KeyLen, sha256.New)
+	block, err := aes.NewCipher(key)
+	if err != nil {
+		return nil, err
+	}
+	if len(info.EncryptedData)%aesBlockLen != 0 {
+		return nil, fmt.Errorf("Encrypted data is not a multiple of the block size")
+	}
+
+	plaintext := make([]byte, len(info.EncryptedData))
+	cipher.NewCBCDecrypter(block, iv).CryptBlocks(plaintext, info.EncryptedData)
+	plaintext, err = pkcs7Unpad(plaintext, aesBlockLen)
+	if err != nil {
+		return nil, fmt.Errorf("Could not decrypt PKCS#8 private key (wrong password?): %w", err)
+	}
+	// A wrong password can still leave bytes that end in valid padding, but
+	// those are all but certain not to form a single well-formed DER value.
+	var raw asn1.RawValue
+	if rest, err := asn1.Unmarshal(plaintext, &raw); err != nil || len(rest) != 0 {
+		return nil, fmt.Errorf("Could not decrypt PKCS#8 private key (wrong password?)")
+	}
+	return plaintext, nil
+}
+
+func pkcs7Pad(data []byte, blockSize int) []byte {
+	padLen := blockSize - len(data)%blockSize
+	padding := make([]byte, padLen)
+	for i := range padding {
+		padding[i] = byte(padLen)
+	}
+	return append(append([]byte{}, data...), padding...)
+}
+
+func pkcs7Unpad(data []byte, blockSize int) ([]byte, error) {
+	if len(data) == 0 || len(data)%blockSize != 0 {
+		return nil, fmt.Errorf("Invalid padded data length")
+	}
+	padLen := int(data[len(data)-1])
+	if padLen == 0 || padLen > blockSize || padLen > len(data) {
+		return nil, fmt.Errorf("Invalid PKCS#7 padding")
+	}
+	return data[:len(data)-padLen], nil
+}
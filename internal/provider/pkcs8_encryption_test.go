@@ -0,0 +1,63 @@
+package provider
+
+import (
+	"bytes"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/x509"
+	"testing"
+)
+
+func testPKCS8DER(t *testing.T) []byte {
+	t.Helper()
+	key, err := rsa.GenerateKey(rand.Reader, 2048)
+	if err != nil {
+		t.Fatalf("GenerateKey: %v", err)
+	}
+	der, err := x509.MarshalPKCS8PrivateKey(key)
+	if err != nil {
+		t.Fatalf("MarshalPKCS8PrivateKey: %v", err)
+	}
+	return der
+}
+
+func TestPKCS8EncryptDecryptRoundTrip(t *testing.T) {
+	der := testPKCS8DER(t)
+
+	encrypted, err := encryptPKCS8(der, []byte("correct horse battery staple"))
+	if err != nil {
+		t.Fatalf("encryptPKCS8: %v", err)
+	}
+	if bytes.Equal(encrypted, der) {
+		t.Fatalf("encryptPKCS8 did not change the DER")
+	}
+
+	decrypted, err := decryptPKCS8(encrypted, []byte("correct horse battery staple"))
+	if err != nil {
+		t.Fatalf("decryptPKCS8: %v", err)
+	}
+	if !bytes.Equal(decrypted, der) {
+		t.Fatalf("decryptPKCS8 did not reproduce the original DER")
+	}
+}
+
+func TestPKCS8DecryptWrongPassword(t *testing.T) {
+	der := testPKCS8DER(t)
+
+	encrypted, err := encryptPKCS8(der, []byte("correct horse battery staple"))
+	if err != nil {
+		t.Fatalf("encryptPKCS8: %v", err)
+	}
+
+	if _, err := decryptPKCS8(encrypted, []byte("wrong password")); err == nil {
+		t.Fatalf("decryptPKCS8 succeeded with the wrong password")
+	}
+}
+
+func TestPKCS8DecryptNotEncryptedPrivateKeyInfo(t *testing.T) {
+	der := testPKCS8DER(t)
+
+	if _, err := decryptPKCS8(der, []byte("anything")); err == nil {
+		t.Fatalf("decryptPKCS8 succeeded on a plain PKCS#8 PrivateKeyInfo")
+	}
+}